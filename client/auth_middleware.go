@@ -0,0 +1,59 @@
+package client
+
+import "net/http"
+
+// TokenSource supplies bearer tokens for BearerTokenMiddleware, e.g. backed by an
+// OAuth2 client-credentials flow or a static token.
+type TokenSource interface {
+	// Token returns the current token, obtaining or refreshing it as needed.
+	Token() (string, error)
+}
+
+// InvalidatableTokenSource is an optional extension of TokenSource. When a
+// TokenSource implements it, BearerTokenMiddleware calls Invalidate before asking for
+// a fresh Token after a 401, so cached tokens aren't handed back unchanged.
+type InvalidatableTokenSource interface {
+	TokenSource
+	// Invalidate discards any cached token so the next Token call fetches a fresh one.
+	Invalidate()
+}
+
+// BearerTokenMiddleware attaches an "Authorization: Bearer <token>" header sourced
+// from source, and retries the request once with a freshly obtained token when the
+// server responds with 401 Unauthorized.
+func BearerTokenMiddleware(source TokenSource) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			token, err := source.Token()
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp, err := next(req)
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+
+			if invalidatable, ok := source.(InvalidatableTokenSource); ok {
+				invalidatable.Invalidate()
+			}
+			token, err = source.Token()
+			if err != nil {
+				return resp, err
+			}
+
+			if req.GetBody != nil {
+				body, gbErr := req.GetBody()
+				if gbErr != nil {
+					return resp, gbErr
+				}
+				req.Body = body
+			}
+			resp.Body.Close()
+
+			req.Header.Set("Authorization", "Bearer "+token)
+			return next(req)
+		}
+	}
+}
@@ -1,18 +1,27 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
+	"time"
 )
 
 // Client is a wrapper for http client performing http requests and handling http responses
 type Client struct {
 	HTTPClient HTTPClient
 	BaseURL    string
+	// RetryPolicy, when set, retries failed requests with backoff. A nil RetryPolicy
+	// (the default) sends each request exactly once.
+	RetryPolicy *RetryPolicy
+	// Codecs selects the decoder used for response bodies based on their Content-Type.
+	// A nil Codecs falls back to a default registry covering JSON, XML and form
+	// bodies, with application/problem+json decoded via JSONCodec.
+	Codecs *CodecRegistry
+	// MaxResponseBytes caps how many bytes of a response body will be read, guarding
+	// against hostile or runaway servers. Zero (the default) means unlimited.
+	MaxResponseBytes int64
+
+	middleware []Middleware
 }
 
 // HTTPClient is an interface to allow mocking of httpClient's Do method
@@ -21,69 +30,115 @@ type HTTPClient interface {
 }
 
 // SendAndConsume sends an http.Request based using the provided url, http method and payload
-// Parses httpResponse body and assigns it to the provided success or error response
+// Parses httpResponse body and assigns it to the provided success or error response.
+// It is a thin wrapper around NewRequest for callers who don't need headers, query
+// parameters or a custom context.
 func (c *Client) SendAndConsume(url string, method string, payload, success, failure interface{}) (*http.Response, error) {
-	req, err := c.createReq(url, method, payload)
-	if err != nil {
-		log.Printf("Failed to create http request. Error was: %s", err.Error())
-		return nil, err
+	return c.NewRequest(method, url).WithBody(payload).Do(success, failure)
+}
+
+// do sends req, retrying according to c.RetryPolicy when one is configured. The
+// request body was buffered at creation time (via http.Request.GetBody) so it can be
+// replayed unchanged on every attempt.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	roundTrip := c.chain()
+
+	policy := c.RetryPolicy
+	if policy == nil {
+		return roundTrip(req)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		log.Printf("Failed to send http request. Error was: %s", err.Error())
-		return resp, err
+	if !policy.RetryNonIdempotent && !idempotentMethods[req.Method] {
+		return roundTrip(req)
 	}
 
-	defer resp.Body.Close()
+	checkRetry := policy.CheckRetry
+	if checkRetry == nil {
+		checkRetry = DefaultCheckRetry
+	}
+	backoff := policy.Backoff
+	if backoff == nil {
+		backoff = DefaultBackoff
+	}
 
-	err = c.consume(resp, success, failure)
-	return resp, err
-}
+	var resp *http.Response
+	var err error
 
-func (c *Client) consume(resp *http.Response, success, failure interface{}) error {
-	defer resp.Body.Close()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, gbErr := req.GetBody()
+			if gbErr != nil {
+				return resp, gbErr
+			}
+			req.Body = body
+		}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+		resp, err = roundTrip(req)
 
-	if isUnsuccessfulStatusCode(resp) {
-		err = json.Unmarshal(respBody, failure)
-		if err != nil {
-			log.Printf("Failed to umarshal error response. Error was: %s", err.Error())
-			return err
+		shouldRetry, checkErr := checkRetry(req.Context(), resp, err)
+		if checkErr != nil {
+			if resp != nil {
+				ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			return resp, checkErr
 		}
-		log.Printf("Request failed due to statusCode: %d", resp.StatusCode)
-		return nil
-	}
 
-	if len(respBody) > 0 {
-		err = json.Unmarshal(respBody, success)
-		if err != nil {
-			log.Printf("Failed to umarshal success response. Error was: %s", err.Error())
-			return err
+		if !shouldRetry || attempt >= policy.MaxRetries {
+			return resp, err
+		}
+
+		if resp != nil {
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff(policy.MinBackoff, policy.MaxBackoff, attempt, resp))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return resp, req.Context().Err()
+		case <-timer.C:
 		}
 	}
-	return nil
 }
 
-func (c *Client) createReq(url string, method string, payload interface{}) (*http.Request, error) {
-	reqURL := c.BaseURL + url
-	var data io.Reader
-	if payload != nil {
-		jsonReq, _ := json.Marshal(payload)
+func (c *Client) consume(resp *http.Response, success, failure interface{}) error {
+	defer resp.Body.Close()
 
-		data = bytes.NewBuffer(jsonReq)
+	respBody, err := c.readBody(resp)
+	if err != nil {
+		if isUnsuccessfulStatusCode(resp) {
+			return &RequestError{StatusCode: resp.StatusCode, Body: respBody, Header: resp.Header, Err: err}
+		}
+		return err
 	}
 
-	req, err := http.NewRequest(method, reqURL, data)
-	if err != nil {
-		log.Printf("Failed to create http request. Error was: %s", err.Error())
-		return nil, err
+	codec := JSONCodec
+	if registered, ok := c.codecs().Lookup(resp.Header.Get("Content-Type")); ok {
+		codec = registered
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if isUnsuccessfulStatusCode(resp) {
+		reqErr := &RequestError{
+			StatusCode: resp.StatusCode,
+			Body:       respBody,
+			Header:     resp.Header,
+		}
+		if len(respBody) > 0 {
+			if unmarshalErr := codec.Decode(respBody, failure); unmarshalErr != nil {
+				reqErr.Err = unmarshalErr
+			}
+		}
+		return reqErr
+	}
 
-	return req, nil
+	if len(respBody) > 0 {
+		if err := codec.Decode(respBody, success); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func isUnsuccessfulStatusCode(resp *http.Response) bool {
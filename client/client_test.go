@@ -2,11 +2,15 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -46,15 +50,8 @@ var form3ErrorHTTPResponse = http.Response{
 	Header:     make(http.Header),
 }
 
-var successResponse *FakeSuccessResponse
-var errorResponse *FakeFailureResponse
-
-var req *http.Request
-
 func TestMain(m *testing.M) {
-	successResponse = &FakeSuccessResponse{}
-	errorResponse = &FakeFailureResponse{}
-	req = &http.Request{}
+	os.Exit(m.Run())
 }
 
 func TestClientSendAndConsumeOKResponse(t *testing.T) {
@@ -68,6 +65,8 @@ func TestClientSendAndConsumeOKResponse(t *testing.T) {
 		BaseURL:    baseURL,
 	}
 
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
 	resp, err := client.SendAndConsume("/some-path", "HTTP_METHOD", data, successResponse, errorResponse)
 
 	assert.NoError(t, err)
@@ -90,6 +89,8 @@ func TestClientConsumeEmptyContentResponse(t *testing.T) {
 		BaseURL:    baseURL,
 	}
 
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
 	resp, err := client.SendAndConsume("/some-path", "HTTP_METHOD", data, successResponse, errorResponse)
 
 	assert.NoError(t, err)
@@ -109,6 +110,8 @@ func TestClientConsumeOKNonJSONResponse(t *testing.T) {
 		HTTPClient: mock,
 		BaseURL:    baseURL,
 	}
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
 	_, err := client.SendAndConsume("/some-path", "HTTP_METHOD", data, successResponse, errorResponse)
 
 	assert.Errorf(t, err, "invalid character 'O' looking for beginning of value")
@@ -124,8 +127,9 @@ func TestClientConsumeAccountErrorResponse(t *testing.T) {
 		HTTPClient: mock,
 		BaseURL:    baseURL,
 	}
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
 	resp, err := client.SendAndConsume("/some-path", "HTTP_METHOD", data, successResponse, errorResponse)
-	assert.NoError(t, err)
 	assert.Equal(t, form3ErrorHTTPResponse, *resp)
 
 	if !reflect.DeepEqual(form3ErrorHTTPResponse, *resp) {
@@ -136,6 +140,12 @@ func TestClientConsumeAccountErrorResponse(t *testing.T) {
 		Failure: "Bad",
 	}
 	assert.Equal(t, want, errorResponse)
+
+	reqErr, ok := IsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 400, reqErr.StatusCode)
+	assert.Equal(t, []byte(`{"failure":"Bad"}`), reqErr.Body)
+	assert.NoError(t, reqErr.Unwrap())
 }
 
 func TestClientConsumeHTTPStatusErrorNonJsonResponse(t *testing.T) {
@@ -148,9 +158,17 @@ func TestClientConsumeHTTPStatusErrorNonJsonResponse(t *testing.T) {
 		HTTPClient: mock,
 		BaseURL:    baseURL,
 	}
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
 	_, err := client.SendAndConsume("/some-path", "HTTP_METHOD", data, successResponse, errorResponse)
 
 	assert.Errorf(t, err, "invalid character 'S' looking for beginning of value")
+
+	reqErr, ok := IsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 500, reqErr.StatusCode)
+	assert.Equal(t, []byte("Some non json error body"), reqErr.Body)
+	assert.Error(t, reqErr.Unwrap())
 }
 
 func TestClientDoErrorResponse(t *testing.T) {
@@ -163,11 +181,259 @@ func TestClientDoErrorResponse(t *testing.T) {
 		HTTPClient: mock,
 		BaseURL:    baseURL,
 	}
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
 	_, err := client.SendAndConsume("/some-path", "HTTP_METHOD", data, successResponse, errorResponse)
 
 	assert.Errorf(t, err, "An expected error")
 }
 
+func TestClientRetriesOnServiceUnavailableThenSucceeds(t *testing.T) {
+	calls := 0
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return &http.Response{
+					StatusCode: 503,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header:     make(http.Header),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"success":"Good"}`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	client := Client{
+		HTTPClient: mock,
+		BaseURL:    baseURL,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 4,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
+	resp, err := client.SendAndConsume("/some-path", http.MethodGet, data, successResponse, errorResponse)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	calls := 0
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: 503,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	client := Client{
+		HTTPClient: mock,
+		BaseURL:    baseURL,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
+	resp, err := client.SendAndConsume("/some-path", http.MethodGet, data, successResponse, errorResponse)
+
+	reqErr, ok := IsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 503, reqErr.StatusCode)
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClientAbortsBackoffWhenContextCancelled(t *testing.T) {
+	calls := 0
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: 503,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	client := Client{
+		HTTPClient: mock,
+		BaseURL:    baseURL,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 5,
+			MinBackoff: time.Hour,
+			MaxBackoff: time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
+
+	done := make(chan struct{})
+	var err error
+	go func() {
+		_, err = client.NewRequest(http.MethodGet, "/some-path").WithContext(ctx).Do(successResponse, errorResponse)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("do() did not return promptly after context cancellation")
+	}
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestClientClosesResponseBodyWhenCheckRetryReturnsError(t *testing.T) {
+	body := &closeTrackingBody{Reader: bytes.NewBufferString("")}
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       body,
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	abort := errors.New("abort retries")
+	client := Client{
+		HTTPClient: mock,
+		BaseURL:    baseURL,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 3,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+			CheckRetry: func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+				return false, abort
+			},
+		},
+	}
+
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
+	_, err := client.SendAndConsume("/some-path", http.MethodGet, data, successResponse, errorResponse)
+
+	assert.ErrorIs(t, err, abort)
+	assert.True(t, body.closed)
+}
+
+func TestClientDoesNotRetryNonIdempotentMethodByDefault(t *testing.T) {
+	calls := 0
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: 503,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	client := Client{
+		HTTPClient:  mock,
+		BaseURL:     baseURL,
+		RetryPolicy: DefaultRetryPolicy(),
+	}
+
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
+	resp, err := client.SendAndConsume("/some-path", http.MethodPost, data, successResponse, errorResponse)
+
+	reqErr, ok := IsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 503, reqErr.StatusCode)
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientHonorsRetryAfterSecondsHeader(t *testing.T) {
+	calls := 0
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				header := make(http.Header)
+				header.Set("Retry-After", "0")
+				return &http.Response{
+					StatusCode: 429,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header:     header,
+				}, nil
+			}
+			return &okHTTPResponse, nil
+		},
+	}
+	client := Client{
+		HTTPClient: mock,
+		BaseURL:    baseURL,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 1,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+
+	successResponse := &FakeSuccessResponse{}
+	errorResponse := &FakeFailureResponse{}
+	resp, err := client.SendAndConsume("/some-path", http.MethodGet, data, successResponse, errorResponse)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRequestErrorMessageAndUnwrap(t *testing.T) {
+	reqErr := &RequestError{
+		StatusCode: 404,
+		Body:       []byte("not found"),
+	}
+
+	assert.Equal(t, "request failed with status: 404 and body: not found", reqErr.Error())
+	assert.NoError(t, reqErr.Unwrap())
+
+	var target *RequestError
+	assert.True(t, errors.As(error(reqErr), &target))
+	assert.Equal(t, reqErr, target)
+}
+
+func TestDefaultBackoffCapsAtMax(t *testing.T) {
+	backoff := DefaultBackoff(time.Second, 2*time.Second, 10, nil)
+	assert.Equal(t, 2*time.Second, backoff)
+}
+
 type HTTPClientMock struct {
 	DoFunc func(req *http.Request) (*http.Response, error)
 }
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+)
+
+// Codec encodes request payloads and decodes response bodies for a particular media
+// type. Client and Request use it to decouple the send path from encoding/json.
+type Codec interface {
+	// Encode marshals v into a request body, returning the bytes and the Content-Type
+	// header value that should accompany them.
+	Encode(v interface{}) ([]byte, string, error)
+	// Decode unmarshals data into v.
+	Decode(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, string, error) {
+	body, err := json.Marshal(v)
+	return body, "application/json", err
+}
+
+func (jsonCodec) Decode(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// JSONCodec encodes and decodes application/json bodies.
+var JSONCodec Codec = jsonCodec{}
+
+type xmlCodec struct{}
+
+func (xmlCodec) Encode(v interface{}) ([]byte, string, error) {
+	body, err := xml.Marshal(v)
+	return body, "application/xml", err
+}
+
+func (xmlCodec) Decode(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return xml.Unmarshal(data, v)
+}
+
+// XMLCodec encodes and decodes application/xml bodies.
+var XMLCodec Codec = xmlCodec{}
+
+type formCodec struct{}
+
+func (formCodec) Encode(v interface{}) ([]byte, string, error) {
+	values, ok := v.(url.Values)
+	if !ok {
+		return nil, "", fmt.Errorf("client: FormCodec requires a url.Values payload, got %T", v)
+	}
+	return []byte(values.Encode()), "application/x-www-form-urlencoded", nil
+}
+
+func (formCodec) Decode(data []byte, v interface{}) error {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	target, ok := v.(*url.Values)
+	if !ok {
+		return fmt.Errorf("client: FormCodec requires a *url.Values target, got %T", v)
+	}
+	*target = values
+	return nil
+}
+
+// FormCodec encodes and decodes application/x-www-form-urlencoded bodies.
+var FormCodec Codec = formCodec{}
+
+// ProblemDetails is an RFC 7807 application/problem+json error body. It can be used
+// as the failure target passed to SendAndConsume/Request.Do.
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// CodecRegistry maps media types to Codecs, used to pick a decoder for a response
+// based on its Content-Type header.
+type CodecRegistry struct {
+	codecs map[string]Codec
+}
+
+// NewCodecRegistry returns a CodecRegistry pre-populated with JSONCodec, XMLCodec,
+// FormCodec, and application/problem+json mapped to JSONCodec so RFC 7807 error
+// bodies decode into a *ProblemDetails failure target out of the box.
+func NewCodecRegistry() *CodecRegistry {
+	r := &CodecRegistry{codecs: make(map[string]Codec)}
+	r.Register("application/json", JSONCodec)
+	r.Register("application/problem+json", JSONCodec)
+	r.Register("application/xml", XMLCodec)
+	r.Register("text/xml", XMLCodec)
+	r.Register("application/x-www-form-urlencoded", FormCodec)
+	return r
+}
+
+// Register associates mediaType with codec, overwriting any previous mapping.
+func (r *CodecRegistry) Register(mediaType string, codec Codec) {
+	r.codecs[mediaType] = codec
+}
+
+// Lookup returns the Codec registered for mediaType, ignoring any parameters (e.g.
+// "application/json; charset=utf-8" matches "application/json").
+func (r *CodecRegistry) Lookup(mediaType string) (Codec, bool) {
+	if mediaType == "" {
+		return nil, false
+	}
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		base = mediaType
+	}
+	codec, ok := r.codecs[base]
+	return codec, ok
+}
+
+var defaultCodecRegistry = NewCodecRegistry()
+
+// codecs returns c.Codecs, falling back to a package-wide default registry when unset.
+func (c *Client) codecs() *CodecRegistry {
+	if c.Codecs != nil {
+		return c.Codecs
+	}
+	return defaultCodecRegistry
+}
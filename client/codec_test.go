@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type xmlPayload struct {
+	XMLName xml.Name `xml:"payload"`
+	Value   string   `xml:"value"`
+}
+
+func TestRequestWithCodecSendsXML(t *testing.T) {
+	var captured *http.Request
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			captured = req
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	_, err := c.NewRequest(http.MethodPost, "/some-path").
+		WithCodec(XMLCodec).
+		WithBody(xmlPayload{Value: "v"}).
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/xml", captured.Header.Get("Content-Type"))
+	body, _ := ioutil.ReadAll(captured.Body)
+	assert.Contains(t, string(body), "<value>v</value>")
+}
+
+func TestConsumeDecodesXMLResponseByContentType(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/xml")
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`<payload><value>hi</value></payload>`)),
+				Header:     header,
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	var success xmlPayload
+	_, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &success, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", success.Value)
+}
+
+func TestConsumeDecodesProblemJSONErrorBody(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/problem+json")
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 400,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"title":"Bad Request","status":400}`)),
+				Header:     header,
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	var problem ProblemDetails
+	_, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &FakeSuccessResponse{}, &problem)
+
+	assert.Error(t, err)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, 400, problem.Status)
+}
+
+func TestFormCodecEncodeAndDecode(t *testing.T) {
+	values := url.Values{"name": {"gopher"}}
+
+	body, contentType, err := FormCodec.Encode(values)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+
+	var decoded url.Values
+	assert.NoError(t, FormCodec.Decode(body, &decoded))
+	assert.Equal(t, "gopher", decoded.Get("name"))
+}
+
+func TestCodecRegistryLookupIgnoresParameters(t *testing.T) {
+	registry := NewCodecRegistry()
+
+	codec, ok := registry.Lookup("application/json; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, JSONCodec, codec)
+
+	_, ok = registry.Lookup("application/octet-stream")
+	assert.False(t, ok)
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger is the logging interface used by LoggingMiddleware. The standard library's
+// *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// LoggingMiddleware logs each outgoing request together with its outcome (status
+// code or error, and duration) using logger. It replaces the log.Printf calls that
+// used to be scattered across the send path.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			if err != nil {
+				logger.Printf("%s %s failed after %s: %s", req.Method, req.URL, time.Since(start), err.Error())
+				return resp, err
+			}
+			logger.Printf("%s %s -> %d in %s", req.Method, req.URL, resp.StatusCode, time.Since(start))
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package client
+
+import "net/http"
+
+// RoundTripFunc sends a single http.Request and returns its response, matching the
+// shape of HTTPClient.Do so middleware can wrap it.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc with additional behaviour such as logging,
+// metrics, tracing, auth-token refresh, request signing or response caching.
+type Middleware func(RoundTripFunc) RoundTripFunc
+
+// Use appends middleware to the Client's chain. Middleware runs in the order given,
+// so the first Middleware passed is the outermost wrapper and sees the request first.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// chain composes the Client's middleware around the underlying HTTPClient.Do.
+func (c *Client) chain() RoundTripFunc {
+	rt := RoundTripFunc(c.HTTPClient.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	return rt
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientUseWrapsRequestInOrder(t *testing.T) {
+	var trace []string
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			trace = append(trace, "transport")
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	mark := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				trace = append(trace, name)
+				return next(req)
+			}
+		}
+	}
+	c.Use(mark("outer"), mark("inner"))
+
+	_, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "transport"}, trace)
+}
+
+type fakeLogger struct {
+	lines []string
+}
+
+func (f *fakeLogger) Printf(format string, v ...interface{}) {
+	f.lines = append(f.lines, format)
+}
+
+func TestLoggingMiddlewareLogsOutcome(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &okHTTPResponse, nil
+		},
+	}
+	logger := &fakeLogger{}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+	c.Use(LoggingMiddleware(logger))
+
+	_, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Len(t, logger.lines, 1)
+}
+
+func TestLoggingMiddlewareLogsErrors(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	logger := &fakeLogger{}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+	c.Use(LoggingMiddleware(logger))
+
+	_, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.Error(t, err)
+	assert.Len(t, logger.lines, 1)
+}
+
+type fakeTokenSource struct {
+	tokens    []string
+	calls     int
+	invalided int
+}
+
+func (f *fakeTokenSource) Token() (string, error) {
+	idx := f.calls
+	if idx >= len(f.tokens) {
+		idx = len(f.tokens) - 1
+	}
+	f.calls++
+	return f.tokens[idx], nil
+}
+
+func (f *fakeTokenSource) Invalidate() {
+	f.invalided++
+}
+
+func TestBearerTokenMiddlewareRefreshesOn401(t *testing.T) {
+	var seenTokens []string
+	calls := 0
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			seenTokens = append(seenTokens, req.Header.Get("Authorization"))
+			if calls == 1 {
+				return &http.Response{
+					StatusCode: 401,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header:     make(http.Header),
+				}, nil
+			}
+			return &okHTTPResponse, nil
+		},
+	}
+	source := &fakeTokenSource{tokens: []string{"stale", "fresh"}}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+	c.Use(BearerTokenMiddleware(source))
+
+	resp, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer stale", "Bearer fresh"}, seenTokens)
+	assert.Equal(t, 1, source.invalided)
+}
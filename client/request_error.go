@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestError is returned by SendAndConsume whenever the server's final response has a
+// non-2xx status code. The raw response body is preserved, even when it could not be
+// unmarshalled into the caller's failure type; if reading it failed, Body holds what was
+// read before the failure. When retrying stops because RetryPolicy.CheckRetry returned
+// an error, or because the request's context ended during a backoff wait, that error is
+// returned instead of a RequestError.
+type RequestError struct {
+	StatusCode int
+	Body       []byte
+	Header     http.Header
+	// Err is set when the response body could not be read in full (e.g. it exceeds
+	// Client.MaxResponseBytes) or could not be unmarshalled into the caller's failure
+	// type (e.g. a non-JSON error body). It is nil when unmarshalling succeeded or the
+	// body was empty.
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request failed with status: %d and body: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap exposes the underlying read or unmarshalling error, if any, so callers can use
+// errors.Is/errors.As to inspect it alongside the status code.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// IsRequestError reports whether err is, or wraps, a *RequestError, returning it via
+// the errors.As convention.
+func IsRequestError(err error) (*RequestError, bool) {
+	var reqErr *RequestError
+	ok := errors.As(err, &reqErr)
+	return reqErr, ok
+}
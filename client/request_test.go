@@ -0,0 +1,173 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestDoSendsHeadersQueryAndAuth(t *testing.T) {
+	var captured *http.Request
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			captured = req
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	resp, err := c.NewRequest(http.MethodGet, "/some-path").
+		WithHeader("X-Custom", "value").
+		WithBearerToken("tok123").
+		WithQuery("page", "2").
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "value", captured.Header.Get("X-Custom"))
+	assert.Equal(t, "Bearer tok123", captured.Header.Get("Authorization"))
+	assert.Equal(t, baseURL+"/some-path?page=2", captured.URL.String())
+}
+
+func TestRequestDefaultsContentTypeOnBodylessRequest(t *testing.T) {
+	var captured *http.Request
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			captured = req
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	_, err := c.NewRequest(http.MethodGet, "/some-path").
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", captured.Header.Get("Content-Type"))
+}
+
+func TestRequestWithBasicAuth(t *testing.T) {
+	var captured *http.Request
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			captured = req
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	_, err := c.NewRequest(http.MethodGet, "/some-path").
+		WithBasicAuth("user", "pass").
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	username, password, ok := captured.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+}
+
+func TestRequestWithContextCancelled(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if err := req.Context().Err(); err != nil {
+				return nil, err
+			}
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.NewRequest(http.MethodGet, "/some-path").
+		WithContext(ctx).
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.Error(t, err)
+}
+
+func TestRequestWithForm(t *testing.T) {
+	var captured *http.Request
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			captured = req
+			body, _ := ioutil.ReadAll(req.Body)
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	form := url.Values{}
+	form.Set("name", "gopher")
+
+	_, err := c.NewRequest(http.MethodPost, "/some-path").
+		WithForm(form).
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", captured.Header.Get("Content-Type"))
+}
+
+func TestRequestWithFormFile(t *testing.T) {
+	var captured *http.Request
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			captured = req
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	_, err := c.NewRequest(http.MethodPost, "/some-path").
+		WithFormFile("file", "report.csv", strings.NewReader("a,b,c")).
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Contains(t, captured.Header.Get("Content-Type"), "multipart/form-data")
+}
+
+func TestRequestDoRetriesUsingGetBody(t *testing.T) {
+	calls := 0
+	var bodies []string
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			if calls < 2 {
+				return &http.Response{
+					StatusCode: 503,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header:     make(http.Header),
+				}, nil
+			}
+			return &okHTTPResponse, nil
+		},
+	}
+	c := Client{
+		HTTPClient: mock,
+		BaseURL:    baseURL,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 2,
+			MinBackoff: 1,
+			MaxBackoff: 1,
+		},
+	}
+
+	_, err := c.NewRequest(http.MethodPut, "/some-path").
+		WithBody(map[string]string{"a": "b"}).
+		Do(&FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, bodies[0], bodies[1])
+}
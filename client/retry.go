@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries performed by a Client when sending requests.
+// A nil RetryPolicy on a Client disables retries entirely, preserving the previous
+// send-once behaviour.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retry attempts after the initial request.
+	MaxRetries int
+	// MinBackoff is the base delay used by Backoff before the first retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay computed by Backoff.
+	MaxBackoff time.Duration
+	// Backoff computes the delay to wait before the given attempt (0-indexed, counting
+	// the first retry). Defaults to DefaultBackoff when nil.
+	Backoff func(min, max time.Duration, attempt int, resp *http.Response) time.Duration
+	// CheckRetry decides whether the outcome of an attempt should be retried. Defaults
+	// to DefaultCheckRetry when nil.
+	CheckRetry func(ctx context.Context, resp *http.Response, err error) (bool, error)
+	// RetryNonIdempotent allows POST and PATCH requests to be retried. They are excluded
+	// by default because replaying them can duplicate side effects on the server.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy modeled after hashicorp/go-retryablehttp:
+// up to 4 retries with exponential backoff and jitter between 1s and 30s, retrying
+// network errors and 429/502/503/504 responses for idempotent methods.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 4,
+		MinBackoff: 1 * time.Second,
+		MaxBackoff: 30 * time.Second,
+		Backoff:    DefaultBackoff,
+		CheckRetry: DefaultCheckRetry,
+	}
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// DefaultCheckRetry retries on network errors and on 429, 502, 503 and 504 responses.
+// It leaves method eligibility (idempotent vs. not) to do, which checks the request
+// method before any retry, since that depends on the request rather than the response.
+func DefaultCheckRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		return true, nil
+	}
+
+	if resp == nil {
+		return false, nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// DefaultBackoff computes an exponential backoff with jitter, doubling per attempt
+// starting from min and capped at max. A Retry-After response header, when present,
+// overrides the computed value.
+func DefaultBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			return retryAfter
+		}
+	}
+
+	backoff := float64(min) * math.Pow(2, float64(attempt))
+	backoff += rand.Float64() * float64(min)
+	if backoff > float64(max) {
+		return max
+	}
+	return time.Duration(backoff)
+}
+
+// parseRetryAfter reads the Retry-After header in either its delay-seconds or
+// HTTP-date form. ok is false when the header is absent or unparseable; a date in the
+// past yields a zero delay.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
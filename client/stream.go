@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// ErrResponseTooLarge is returned when a response body exceeds Client.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("client: response body exceeds MaxResponseBytes limit")
+
+// readBody reads resp.Body in full, enforcing c.MaxResponseBytes when set.
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
+	if c.MaxResponseBytes <= 0 {
+		return ioutil.ReadAll(resp.Body)
+	}
+	return ioutil.ReadAll(&limitedReader{r: resp.Body, limit: c.MaxResponseBytes})
+}
+
+// limitedReader wraps an io.Reader and fails with ErrResponseTooLarge as soon as more
+// than limit bytes have been read, rather than silently truncating like io.LimitReader.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// SendAndStream sends req and, on a successful (2xx) response, hands the raw,
+// unbuffered response body to handler instead of unmarshalling it. This is suited to
+// large or streaming responses (SSE, NDJSON, file downloads) that shouldn't be fully
+// buffered in memory. c.MaxResponseBytes, when set, still bounds how much of the body
+// can be read before ErrResponseTooLarge is returned. On a non-2xx response, handler
+// is not invoked and a *RequestError is returned instead, with Body populated from the
+// (bounded) response. If reading that body itself fails (e.g. it exceeds
+// MaxResponseBytes), the read error is surfaced via RequestError.Err rather than
+// silently dropped.
+func (c *Client) SendAndStream(req *Request, handler func(io.Reader, *http.Response) error) (*http.Response, error) {
+	resp, err := req.send()
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	if isUnsuccessfulStatusCode(resp) {
+		body, readErr := c.readBody(resp)
+		return resp, &RequestError{StatusCode: resp.StatusCode, Body: body, Header: resp.Header, Err: readErr}
+	}
+
+	var body io.Reader = resp.Body
+	if c.MaxResponseBytes > 0 {
+		body = &limitedReader{r: resp.Body, limit: c.MaxResponseBytes}
+	}
+
+	return resp, handler(body, resp)
+}
+
+// SendAndDecodeStream sends req and decodes a successful response body as a stream of
+// JSON values, calling onItem once per value. It accepts either newline-delimited JSON
+// (NDJSON) or a single top-level JSON array, auto-detecting which based on the first
+// non-whitespace byte. newItem must return a fresh pointer to decode each value into.
+func (c *Client) SendAndDecodeStream(req *Request, newItem func() interface{}, onItem func(interface{}) error) (*http.Response, error) {
+	return c.SendAndStream(req, func(body io.Reader, resp *http.Response) error {
+		return decodeJSONStream(body, newItem, onItem)
+	})
+}
+
+func decodeJSONStream(r io.Reader, newItem func() interface{}, onItem func(interface{}) error) error {
+	br := bufio.NewReader(r)
+
+	first, err := peekNonSpace(br)
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	decoder := json.NewDecoder(br)
+
+	if first == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+		for decoder.More() {
+			item := newItem()
+			if err := decoder.Decode(item); err != nil {
+				return err
+			}
+			if err := onItem(item); err != nil {
+				return err
+			}
+		}
+		_, err := decoder.Token()
+		return err
+	}
+
+	for {
+		item := newItem()
+		if err := decoder.Decode(item); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := onItem(item); err != nil {
+			return err
+		}
+	}
+}
+
+// peekNonSpace discards leading JSON whitespace and returns the first meaningful byte.
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\n', '\r':
+			if _, err := br.Discard(1); err != nil {
+				return 0, err
+			}
+			continue
+		default:
+			return b[0], nil
+		}
+	}
+}
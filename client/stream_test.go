@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendAndStreamYieldsRawBody(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("line1\nline2\n")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	var got []byte
+	resp, err := c.SendAndStream(c.NewRequest(http.MethodGet, "/events"), func(body io.Reader, resp *http.Response) error {
+		var readErr error
+		got, readErr = ioutil.ReadAll(body)
+		return readErr
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "line1\nline2\n", string(got))
+}
+
+func TestSendAndStreamReturnsRequestErrorOnFailureStatus(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 503,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("unavailable")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	called := false
+	_, err := c.SendAndStream(c.NewRequest(http.MethodGet, "/events"), func(body io.Reader, resp *http.Response) error {
+		called = true
+		return nil
+	})
+
+	assert.False(t, called)
+	reqErr, ok := IsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 503, reqErr.StatusCode)
+	assert.Equal(t, []byte("unavailable"), reqErr.Body)
+}
+
+func TestSendAndStreamSurfacesMaxResponseBytesOnFailureStatus(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 503,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("way too much unavailable")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL, MaxResponseBytes: 4}
+
+	called := false
+	_, err := c.SendAndStream(c.NewRequest(http.MethodGet, "/events"), func(body io.Reader, resp *http.Response) error {
+		called = true
+		return nil
+	})
+
+	assert.False(t, called)
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	reqErr, ok := IsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 503, reqErr.StatusCode)
+}
+
+func TestReadBodyEnforcesMaxResponseBytes(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"success":"waytoobig"}`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL, MaxResponseBytes: 4}
+
+	_, err := c.SendAndConsume("/some-path", http.MethodGet, nil, &FakeSuccessResponse{}, &FakeFailureResponse{})
+
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+type streamItem struct {
+	ID int `json:"id"`
+}
+
+func TestSendAndDecodeStreamHandlesNDJSON(t *testing.T) {
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	var ids []int
+	_, err := c.SendAndDecodeStream(
+		c.NewRequest(http.MethodGet, "/events"),
+		func() interface{} { return &streamItem{} },
+		func(v interface{}) error {
+			ids = append(ids, v.(*streamItem).ID)
+			return nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestSendAndDecodeStreamHandlesJSONArray(t *testing.T) {
+	payload, _ := json.Marshal([]streamItem{{ID: 1}, {ID: 2}})
+	mock := &HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewReader(payload)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	c := Client{HTTPClient: mock, BaseURL: baseURL}
+
+	var ids []int
+	_, err := c.SendAndDecodeStream(
+		c.NewRequest(http.MethodGet, "/events"),
+		func() interface{} { return &streamItem{} },
+		func(v interface{}) error {
+			ids = append(ids, v.(*streamItem).ID)
+			return nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+}